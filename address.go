@@ -0,0 +1,72 @@
+package main
+
+import (
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/pkg/errors"
+	v1 "k8s.io/api/core/v1"
+)
+
+const addressTypeAnnotation = "endpoints-operator.fnox.se/address-type"
+const addressCIDRAnnotation = "endpoints-operator.fnox.se/address-cidr"
+
+var defaultAddressPriority = []v1.NodeAddressType{v1.NodeInternalIP, v1.NodeExternalIP}
+
+// addressPriorityFromString parses the endpoints-operator.fnox.se/address-type
+// annotation value (or a NodeEndpointBinding's AddressType field), a
+// comma-separated priority list of NodeAddressType (InternalIP, ExternalIP,
+// Hostname, InternalDNS, ExternalDNS), falling back to the historical
+// InternalIP -> ExternalIP order when unset.
+func addressPriorityFromString(raw string) []v1.NodeAddressType {
+	if raw == "" {
+		return defaultAddressPriority
+	}
+
+	parts := strings.Split(raw, ",")
+	priority := make([]v1.NodeAddressType, 0, len(parts))
+	for _, p := range parts {
+		priority = append(priority, v1.NodeAddressType(strings.TrimSpace(p)))
+	}
+	return priority
+}
+
+// addressCIDRFromString parses the endpoints-operator.fnox.se/address-cidr
+// annotation value (or a NodeEndpointBinding's AddressCIDR field), used to pick
+// the right address on multi-NIC nodes that report several addresses of the
+// same type.
+func addressCIDRFromString(raw string) (*net.IPNet, error) {
+	if raw == "" {
+		return nil, nil
+	}
+
+	_, cidr, err := net.ParseCIDR(raw)
+	if err != nil {
+		return nil, errors.Wrapf(err, "parsing %s annotation", addressCIDRAnnotation)
+	}
+	return cidr, nil
+}
+
+// nodeAddress picks a node's address using the given type priority, optionally
+// restricted to addresses inside cidr.
+func nodeAddress(node v1.Node, priority []v1.NodeAddressType, cidr *net.IPNet) (string, error) {
+	m := map[v1.NodeAddressType][]string{}
+	for _, a := range node.Status.Addresses {
+		m[a.Type] = append(m[a.Type], a.Address)
+	}
+
+	for _, t := range priority {
+		for _, addr := range m[t] {
+			if cidr == nil {
+				return addr, nil
+			}
+			ip := net.ParseIP(addr)
+			if ip != nil && cidr.Contains(ip) {
+				return addr, nil
+			}
+		}
+	}
+
+	return "", fmt.Errorf("no address matching priority %v (cidr %v) found", priority, cidr)
+}
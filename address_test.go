@@ -0,0 +1,98 @@
+package main
+
+import (
+	"net"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+func TestAddressPriorityFromString(t *testing.T) {
+	tests := []struct {
+		raw  string
+		want []v1.NodeAddressType
+	}{
+		{"", defaultAddressPriority},
+		{"ExternalIP", []v1.NodeAddressType{v1.NodeExternalIP}},
+		{"ExternalIP, Hostname", []v1.NodeAddressType{v1.NodeExternalIP, v1.NodeHostName}},
+	}
+
+	for _, tt := range tests {
+		got := addressPriorityFromString(tt.raw)
+		if len(got) != len(tt.want) {
+			t.Fatalf("addressPriorityFromString(%q) = %v, want %v", tt.raw, got, tt.want)
+		}
+		for i := range got {
+			if got[i] != tt.want[i] {
+				t.Errorf("addressPriorityFromString(%q) = %v, want %v", tt.raw, got, tt.want)
+			}
+		}
+	}
+}
+
+func TestAddressCIDRFromString(t *testing.T) {
+	cidr, err := addressCIDRFromString("10.0.0.0/24")
+	if err != nil {
+		t.Fatalf("addressCIDRFromString() error = %v", err)
+	}
+	if !cidr.Contains(net.ParseIP("10.0.0.5")) {
+		t.Errorf("expected cidr to contain 10.0.0.5")
+	}
+
+	if cidr, err := addressCIDRFromString(""); err != nil || cidr != nil {
+		t.Errorf("addressCIDRFromString(\"\") = (%v, %v), want (nil, nil)", cidr, err)
+	}
+
+	if _, err := addressCIDRFromString("not-a-cidr"); err == nil {
+		t.Error("addressCIDRFromString() expected error for malformed CIDR, got nil")
+	}
+}
+
+func TestNodeAddress(t *testing.T) {
+	node := v1.Node{
+		Status: v1.NodeStatus{
+			Addresses: []v1.NodeAddress{
+				{Type: v1.NodeInternalIP, Address: "10.0.0.1"},
+				{Type: v1.NodeInternalIP, Address: "10.0.1.1"},
+				{Type: v1.NodeExternalIP, Address: "1.2.3.4"},
+			},
+		},
+	}
+
+	t.Run("picks first priority type", func(t *testing.T) {
+		addr, err := nodeAddress(node, []v1.NodeAddressType{v1.NodeInternalIP, v1.NodeExternalIP}, nil)
+		if err != nil {
+			t.Fatalf("nodeAddress() error = %v", err)
+		}
+		if addr != "10.0.0.1" {
+			t.Errorf("nodeAddress() = %q, want %q", addr, "10.0.0.1")
+		}
+	})
+
+	t.Run("falls back to next type", func(t *testing.T) {
+		addr, err := nodeAddress(node, []v1.NodeAddressType{v1.NodeHostName, v1.NodeExternalIP}, nil)
+		if err != nil {
+			t.Fatalf("nodeAddress() error = %v", err)
+		}
+		if addr != "1.2.3.4" {
+			t.Errorf("nodeAddress() = %q, want %q", addr, "1.2.3.4")
+		}
+	})
+
+	t.Run("restricted to cidr", func(t *testing.T) {
+		_, cidr, _ := net.ParseCIDR("10.0.1.0/24")
+		addr, err := nodeAddress(node, []v1.NodeAddressType{v1.NodeInternalIP}, cidr)
+		if err != nil {
+			t.Fatalf("nodeAddress() error = %v", err)
+		}
+		if addr != "10.0.1.1" {
+			t.Errorf("nodeAddress() = %q, want %q", addr, "10.0.1.1")
+		}
+	})
+
+	t.Run("no matching address", func(t *testing.T) {
+		if _, err := nodeAddress(node, []v1.NodeAddressType{v1.NodeHostName}, nil); err == nil {
+			t.Error("nodeAddress() expected error when no address matches, got nil")
+		}
+	})
+}
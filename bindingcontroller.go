@@ -0,0 +1,228 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+
+	versioned "github.com/fortnoxab/endpoints-operator/pkg/client/clientset/versioned"
+	externalversions "github.com/fortnoxab/endpoints-operator/pkg/client/informers/externalversions"
+	listers "github.com/fortnoxab/endpoints-operator/pkg/client/listers/endpointsoperator/v1alpha1"
+)
+
+const bindingResyncPeriod = 10 * time.Minute
+
+// defaultBindingWorkerCount is how many worker goroutines drain the queue when
+// the caller doesn't override it via NewNodeEndpointBindingControllerWithWorkers.
+const defaultBindingWorkerCount = 2
+
+// NodeEndpointBindingController is the native counterpart to the annotation
+// shim Controller: it watches NodeEndpointBinding objects directly and
+// reconciles each one through reconcileBinding. Like the shim, it also watches
+// Nodes and Services so a node join/leave/taint change or an edit to a
+// binding's target Service is reflected within a workqueue resync instead of
+// only every bindingResyncPeriod.
+type NodeEndpointBindingController struct {
+	factory       externalversions.SharedInformerFactory
+	sharedFactory informers.SharedInformerFactory
+
+	lister listers.NodeEndpointBindingLister
+	synced cache.InformerSynced
+
+	nodeSynced    cache.InformerSynced
+	serviceSynced cache.InformerSynced
+
+	queue workqueue.RateLimitingInterface
+
+	workers int
+}
+
+// NewNodeEndpointBindingController builds a NodeEndpointBindingController with
+// defaultBindingWorkerCount worker goroutines. Use
+// NewNodeEndpointBindingControllerWithWorkers to override that.
+func NewNodeEndpointBindingController(client versioned.Interface, kubeClient kubernetes.Interface) *NodeEndpointBindingController {
+	return NewNodeEndpointBindingControllerWithWorkers(client, kubeClient, defaultBindingWorkerCount)
+}
+
+// NewNodeEndpointBindingControllerWithWorkers builds a
+// NodeEndpointBindingController whose queue is drained by workers goroutines
+// running in parallel.
+func NewNodeEndpointBindingControllerWithWorkers(client versioned.Interface, kubeClient kubernetes.Interface, workers int) *NodeEndpointBindingController {
+	factory := externalversions.NewSharedInformerFactory(client, bindingResyncPeriod)
+	bindingInformer := factory.Endpointsoperator().NodeEndpointBindings()
+
+	// Nodes and Services aren't filtered server-side: any node change can affect
+	// an already-reconciled binding regardless of its nodeSelector, and any
+	// binding's targetServiceRef could point at a changed Service.
+	sharedFactory := informers.NewSharedInformerFactory(kubeClient, bindingResyncPeriod)
+	nodeInformer := sharedFactory.Core().V1().Nodes()
+	serviceInformer := sharedFactory.Core().V1().Services()
+
+	c := &NodeEndpointBindingController{
+		factory:       factory,
+		sharedFactory: sharedFactory,
+		lister:        bindingInformer.Lister(),
+		synced:        bindingInformer.Informer().HasSynced,
+		nodeSynced:    nodeInformer.Informer().HasSynced,
+		serviceSynced: serviceInformer.Informer().HasSynced,
+		queue:         workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter()),
+		workers:       workers,
+	}
+
+	bindingInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    c.enqueue,
+		UpdateFunc: func(old, new interface{}) { c.enqueue(new) },
+		DeleteFunc: c.enqueue,
+	})
+
+	nodeInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    func(obj interface{}) { c.enqueueAllBindings() },
+		UpdateFunc: func(old, new interface{}) { c.enqueueAllBindings() },
+		DeleteFunc: func(obj interface{}) { c.enqueueAllBindings() },
+	})
+
+	serviceInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    c.enqueueBindingsForService,
+		UpdateFunc: func(old, new interface{}) { c.enqueueBindingsForService(new) },
+		DeleteFunc: c.enqueueBindingsForService,
+	})
+
+	return c
+}
+
+func (c *NodeEndpointBindingController) enqueue(obj interface{}) {
+	key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
+	if err != nil {
+		utilruntime.HandleError(err)
+		return
+	}
+	c.queue.Add(key)
+}
+
+// enqueueAllBindings re-enqueues every known binding, used when a Node changes
+// since any binding's nodeSelector could match it regardless of namespace.
+func (c *NodeEndpointBindingController) enqueueAllBindings() {
+	bindings, err := c.lister.List(labels.Everything())
+	if err != nil {
+		utilruntime.HandleError(fmt.Errorf("listing nodeendpointbindings failed: %w", err))
+		return
+	}
+	for _, binding := range bindings {
+		key, err := cache.MetaNamespaceKeyFunc(binding)
+		if err != nil {
+			utilruntime.HandleError(err)
+			continue
+		}
+		c.queue.Add(key)
+	}
+}
+
+// enqueueBindingsForService re-enqueues every binding whose targetServiceRef
+// points at the changed Service.
+func (c *NodeEndpointBindingController) enqueueBindingsForService(obj interface{}) {
+	svc, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
+	if err != nil {
+		utilruntime.HandleError(err)
+		return
+	}
+	namespace, name, err := cache.SplitMetaNamespaceKey(svc)
+	if err != nil {
+		utilruntime.HandleError(err)
+		return
+	}
+
+	bindings, err := c.lister.List(labels.Everything())
+	if err != nil {
+		utilruntime.HandleError(fmt.Errorf("listing nodeendpointbindings failed: %w", err))
+		return
+	}
+	for _, binding := range bindings {
+		targetNamespace := binding.Spec.TargetServiceRef.Namespace
+		if targetNamespace == "" {
+			targetNamespace = binding.Namespace
+		}
+		if targetNamespace != namespace || binding.Spec.TargetServiceRef.Name != name {
+			continue
+		}
+
+		key, err := cache.MetaNamespaceKeyFunc(binding)
+		if err != nil {
+			utilruntime.HandleError(err)
+			continue
+		}
+		c.queue.Add(key)
+	}
+}
+
+// Run starts the informers, waits for their caches to sync and then runs
+// workers until stopc is closed.
+func (c *NodeEndpointBindingController) Run(stopc <-chan struct{}) {
+	defer utilruntime.HandleCrash()
+	defer c.queue.ShutDown()
+
+	logrus.Info("starting nodeendpointbinding controller")
+
+	c.factory.Start(stopc)
+	c.sharedFactory.Start(stopc)
+
+	if !cache.WaitForCacheSync(stopc, c.synced, c.nodeSynced, c.serviceSynced) {
+		utilruntime.HandleError(fmt.Errorf("timed out waiting for nodeendpointbinding cache to sync"))
+		return
+	}
+
+	for i := 0; i < c.workers; i++ {
+		go wait.Until(c.runWorker, time.Second, stopc)
+	}
+
+	<-stopc
+	logrus.Info("shutting down nodeendpointbinding controller")
+}
+
+func (c *NodeEndpointBindingController) runWorker() {
+	for c.processNextWorkItem() {
+	}
+}
+
+func (c *NodeEndpointBindingController) processNextWorkItem() bool {
+	key, shutdown := c.queue.Get()
+	if shutdown {
+		return false
+	}
+	defer c.queue.Done(key)
+
+	if err := c.sync(key.(string)); err != nil {
+		c.queue.AddRateLimited(key)
+		utilruntime.HandleError(fmt.Errorf("syncing %q failed, will retry: %w", key, err))
+		return true
+	}
+
+	c.queue.Forget(key)
+	return true
+}
+
+func (c *NodeEndpointBindingController) sync(key string) error {
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		return err
+	}
+
+	binding, err := c.lister.NodeEndpointBindings(namespace).Get(name)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			logrus.Debugf("nodeendpointbinding %s no longer exists, skipping", key)
+			return nil
+		}
+		return err
+	}
+
+	return reconcileBinding(binding)
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+
+	endpointsoperatorv1alpha1 "github.com/fortnoxab/endpoints-operator/pkg/apis/endpointsoperator/v1alpha1"
+	listers "github.com/fortnoxab/endpoints-operator/pkg/client/listers/endpointsoperator/v1alpha1"
+)
+
+func newBindingLister(t *testing.T, bindings ...*endpointsoperatorv1alpha1.NodeEndpointBinding) listers.NodeEndpointBindingLister {
+	t.Helper()
+
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	for _, b := range bindings {
+		if err := indexer.Add(b); err != nil {
+			t.Fatalf("indexer.Add() error = %v", err)
+		}
+	}
+	return listers.NewNodeEndpointBindingLister(indexer)
+}
+
+func TestBindingControllerEnqueueAllBindings(t *testing.T) {
+	a := &endpointsoperatorv1alpha1.NodeEndpointBinding{ObjectMeta: metav1.ObjectMeta{Namespace: "ns1", Name: "a"}}
+	b := &endpointsoperatorv1alpha1.NodeEndpointBinding{ObjectMeta: metav1.ObjectMeta{Namespace: "ns2", Name: "b"}}
+
+	c := &NodeEndpointBindingController{
+		lister: newBindingLister(t, a, b),
+		queue:  workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter()),
+	}
+
+	c.enqueueAllBindings()
+
+	keys := drainQueueKeys(t, c.queue)
+	if len(keys) != 2 {
+		t.Fatalf("enqueueAllBindings() enqueued %v, want 2 keys", keys)
+	}
+}
+
+func TestBindingControllerEnqueueBindingsForService(t *testing.T) {
+	matching := &endpointsoperatorv1alpha1.NodeEndpointBinding{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "matching"},
+		Spec: endpointsoperatorv1alpha1.NodeEndpointBindingSpec{
+			TargetServiceRef: endpointsoperatorv1alpha1.ServiceReference{Name: "my-svc"},
+		},
+	}
+	matchingCrossNamespace := &endpointsoperatorv1alpha1.NodeEndpointBinding{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "other-ns", Name: "cross-ns"},
+		Spec: endpointsoperatorv1alpha1.NodeEndpointBindingSpec{
+			TargetServiceRef: endpointsoperatorv1alpha1.ServiceReference{Namespace: "ns", Name: "my-svc"},
+		},
+	}
+	nonMatching := &endpointsoperatorv1alpha1.NodeEndpointBinding{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "unrelated"},
+		Spec: endpointsoperatorv1alpha1.NodeEndpointBindingSpec{
+			TargetServiceRef: endpointsoperatorv1alpha1.ServiceReference{Name: "other-svc"},
+		},
+	}
+
+	c := &NodeEndpointBindingController{
+		lister: newBindingLister(t, matching, matchingCrossNamespace, nonMatching),
+		queue:  workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter()),
+	}
+
+	c.enqueueBindingsForService(&metav1.ObjectMeta{Namespace: "ns", Name: "my-svc"})
+
+	keys := drainQueueKeys(t, c.queue)
+	want := map[string]bool{"ns/matching": true, "other-ns/cross-ns": true}
+	if len(keys) != len(want) {
+		t.Fatalf("enqueueBindingsForService() enqueued %v, want keys for %v", keys, want)
+	}
+	for _, k := range keys {
+		if !want[k] {
+			t.Errorf("enqueueBindingsForService() unexpectedly enqueued %q", k)
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	endpointsoperatorv1alpha1 "github.com/fortnoxab/endpoints-operator/pkg/apis/endpointsoperator/v1alpha1"
+	nodesync "github.com/fortnoxab/endpoints-operator/pkg/sync"
+)
+
+// reconcileBinding is the single code path both the native NodeEndpointBinding
+// controller and the legacy annotation shim funnel through, so CRD-backed and
+// annotation-backed services stay in sync via the same logic.
+func reconcileBinding(binding *endpointsoperatorv1alpha1.NodeEndpointBinding) error {
+	targetNamespace := binding.Spec.TargetServiceRef.Namespace
+	if targetNamespace == "" {
+		targetNamespace = binding.Namespace
+	}
+
+	service, err := kubeClient.Services(targetNamespace).Get(binding.Spec.TargetServiceRef.Name, metav1.GetOptions{})
+	if err != nil {
+		return errors.Wrapf(err, "getting target service %s/%s failed", targetNamespace, binding.Spec.TargetServiceRef.Name)
+	}
+
+	selector, err := bindingNodeSelector(binding)
+	if err != nil {
+		return errors.Wrap(err, "parsing nodeSelector failed")
+	}
+
+	nodes, err := kubeClient.Nodes().List(metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return errors.Wrap(err, "listing nodes failed")
+	}
+
+	priority := addressPriorityFromString(binding.Spec.AddressType)
+	cidr, err := addressCIDRFromString(binding.Spec.AddressCIDR)
+	if err != nil {
+		return err
+	}
+
+	addresses, errs := getNodeAddresses(nodes, service.Name, binding.Spec.Tolerations, priority, cidr)
+	for _, err := range errs {
+		logrus.Warnf("error getting node address: %s", err)
+	}
+
+	ports := portsFromBinding(binding.Spec.Ports, service)
+	addresses = applyProbes(service, ports, addresses)
+
+	target := nodesync.Target{
+		Namespace: service.GetNamespace(),
+		Name:      service.Name,
+		Labels:    service.Labels,
+		Ports:     ports,
+		Addresses: addresses,
+	}
+
+	endpointsWriter := nodesync.NewEndpointsWriter(kubeClient.Endpoints(service.GetNamespace()))
+	endpointSliceWriter := nodesync.NewEndpointSliceWriter(discoveryClient.EndpointSlices(service.GetNamespace()))
+
+	var writer, staleWriter nodesync.Writer
+	if binding.Spec.EndpointSliceMode {
+		writer, staleWriter = endpointSliceWriter, endpointsWriter
+	} else {
+		writer, staleWriter = endpointsWriter, endpointSliceWriter
+	}
+
+	if err := writer.Upsert(target); err != nil {
+		return errors.Wrap(err, "synchronizing endpoints failed")
+	}
+
+	// Clean up whatever the other resource kind left behind the last time this
+	// Service (or binding) synced in the other mode, so toggling
+	// endpointSliceMode doesn't orphan stale Endpoints/EndpointSlices.
+	if err := staleWriter.Delete(service.Name); err != nil {
+		return errors.Wrap(err, "cleaning up stale endpoints from a previous sync mode failed")
+	}
+
+	recordSuccessfulSync()
+	return nil
+}
+
+// portsFromBinding builds the published port list from the binding spec,
+// falling back to the target Service's own ports when the binding doesn't list
+// any, so a minimal binding still does something useful.
+func portsFromBinding(ports []endpointsoperatorv1alpha1.PortBinding, service *v1.Service) []v1.EndpointPort {
+	if len(ports) == 0 {
+		return endpointPorts(service, nil)
+	}
+
+	result := make([]v1.EndpointPort, 0, len(ports))
+	for _, p := range ports {
+		port := p.Port
+		if p.NodePortOverride != nil {
+			port = *p.NodePortOverride
+		}
+		result = append(result, v1.EndpointPort{Name: p.Name, Port: port})
+	}
+	return result
+}
@@ -0,0 +1,203 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+)
+
+const resyncPeriod = 10 * time.Minute
+
+// defaultWorkerCount is how many worker goroutines drain the queue when the
+// caller doesn't override it via NewControllerWithWorkers.
+const defaultWorkerCount = 2
+
+// Controller is the backward-compatibility shim for services still configured via
+// endpoints-operator.fnox.se/* annotations rather than a NodeEndpointBinding. It
+// watches Services, Nodes and Endpoints via shared informers and, for every
+// annotated Service, translates its annotations into an ephemeral in-memory
+// NodeEndpointBinding and reconciles it through the same reconcileBinding path the
+// native NodeEndpointBindingController uses. Changes to any of the three resources
+// are coalesced through a rate-limited workqueue keyed by "namespace/name" of the
+// owning Service, so bursts of node churn collapse into a single resync per
+// service instead of one per event.
+type Controller struct {
+	serviceFactory informers.SharedInformerFactory
+	sharedFactory  informers.SharedInformerFactory
+
+	serviceLister  corelisters.ServiceLister
+	serviceSynced  cache.InformerSynced
+	nodeSynced     cache.InformerSynced
+	endpointSynced cache.InformerSynced
+
+	queue workqueue.RateLimitingInterface
+
+	workers int
+}
+
+// NewController builds a Controller with defaultWorkerCount worker goroutines.
+// Use NewControllerWithWorkers to override that.
+func NewController(clientset kubernetes.Interface) *Controller {
+	return NewControllerWithWorkers(clientset, defaultWorkerCount)
+}
+
+// NewControllerWithWorkers builds a Controller whose queue is drained by
+// workers goroutines running in parallel.
+func NewControllerWithWorkers(clientset kubernetes.Interface, workers int) *Controller {
+	// Services get a server-side label selector so we only ever hear about the
+	// ones opted in via endpoints-operator.fnox.se/enabled=true.
+	serviceFactory := informers.NewSharedInformerFactoryWithOptions(clientset, resyncPeriod,
+		informers.WithTweakListOptions(func(opts *metav1.ListOptions) {
+			opts.LabelSelector = labels.Set(endpointsSyncEnabledService.MatchLabels).String()
+		}),
+	)
+	serviceInformer := serviceFactory.Core().V1().Services()
+
+	// Nodes and Endpoints aren't filtered server-side since any node or endpoints
+	// change can affect an already-selected service.
+	sharedFactory := informers.NewSharedInformerFactory(clientset, resyncPeriod)
+	nodeInformer := sharedFactory.Core().V1().Nodes()
+	endpointInformer := sharedFactory.Core().V1().Endpoints()
+
+	c := &Controller{
+		serviceFactory: serviceFactory,
+		sharedFactory:  sharedFactory,
+		serviceLister:  serviceInformer.Lister(),
+		serviceSynced:  serviceInformer.Informer().HasSynced,
+		nodeSynced:     nodeInformer.Informer().HasSynced,
+		endpointSynced: endpointInformer.Informer().HasSynced,
+		queue:          workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter()),
+		workers:        workers,
+	}
+
+	serviceInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    c.enqueueService,
+		UpdateFunc: func(old, new interface{}) { c.enqueueService(new) },
+		DeleteFunc: c.enqueueService,
+	})
+
+	nodeInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    func(obj interface{}) { c.enqueueAllServices() },
+		UpdateFunc: func(old, new interface{}) { c.enqueueAllServices() },
+		DeleteFunc: func(obj interface{}) { c.enqueueAllServices() },
+	})
+
+	endpointInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		// Re-reconcile if something external touches the Endpoints we manage.
+		UpdateFunc: func(old, new interface{}) { c.enqueueService(new) },
+	})
+
+	return c
+}
+
+func (c *Controller) enqueueService(obj interface{}) {
+	key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
+	if err != nil {
+		utilruntime.HandleError(err)
+		return
+	}
+	c.queue.Add(key)
+}
+
+func (c *Controller) enqueueAllServices() {
+	services, err := c.serviceLister.List(labels.Set(endpointsSyncEnabledService.MatchLabels).AsSelector())
+	if err != nil {
+		utilruntime.HandleError(fmt.Errorf("listing services failed: %w", err))
+		return
+	}
+	for _, svc := range services {
+		key, err := cache.MetaNamespaceKeyFunc(svc)
+		if err != nil {
+			utilruntime.HandleError(err)
+			continue
+		}
+		c.queue.Add(key)
+	}
+}
+
+// Run starts the informers, waits for their caches to sync and then runs
+// workers until stopc is closed.
+func (c *Controller) Run(stopc <-chan struct{}) {
+	defer utilruntime.HandleCrash()
+	defer c.queue.ShutDown()
+
+	logrus.Info("starting endpoints-operator controller")
+
+	c.serviceFactory.Start(stopc)
+	c.sharedFactory.Start(stopc)
+
+	if !cache.WaitForCacheSync(stopc, c.serviceSynced, c.nodeSynced, c.endpointSynced) {
+		utilruntime.HandleError(fmt.Errorf("timed out waiting for caches to sync"))
+		return
+	}
+
+	for i := 0; i < c.workers; i++ {
+		go wait.Until(c.runWorker, time.Second, stopc)
+	}
+
+	<-stopc
+	logrus.Info("shutting down endpoints-operator controller")
+}
+
+func (c *Controller) runWorker() {
+	for c.processNextWorkItem() {
+	}
+}
+
+func (c *Controller) processNextWorkItem() bool {
+	key, shutdown := c.queue.Get()
+	if shutdown {
+		return false
+	}
+	defer c.queue.Done(key)
+
+	if err := c.sync(key.(string)); err != nil {
+		c.queue.AddRateLimited(key)
+		utilruntime.HandleError(fmt.Errorf("syncing %q failed, will retry: %w", key, err))
+		return true
+	}
+
+	c.queue.Forget(key)
+	return true
+}
+
+func (c *Controller) sync(key string) error {
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		return err
+	}
+
+	svc, err := c.serviceLister.Services(namespace).Get(name)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			logrus.Debugf("service %s no longer exists, skipping", key)
+			return nil
+		}
+		return err
+	}
+
+	nodeSelector, ok := svc.Annotations[nodeSelectorLabel]
+	if !ok {
+		logrus.Errorf("missing %s annotation on service %s", nodeSelectorLabel, key)
+		return nil
+	}
+
+	binding, err := ephemeralBindingFromService(svc, nodeSelector)
+	if err != nil {
+		return errors.Wrap(err, "building ephemeral binding from annotations failed")
+	}
+
+	return reconcileBinding(binding)
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+)
+
+func newServiceLister(t *testing.T, services ...*v1.Service) corelisters.ServiceLister {
+	t.Helper()
+
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	for _, svc := range services {
+		if err := indexer.Add(svc); err != nil {
+			t.Fatalf("indexer.Add() error = %v", err)
+		}
+	}
+	return corelisters.NewServiceLister(indexer)
+}
+
+func drainQueueKeys(t *testing.T, queue workqueue.RateLimitingInterface) []string {
+	t.Helper()
+
+	var keys []string
+	for queue.Len() > 0 {
+		key, shutdown := queue.Get()
+		if shutdown {
+			break
+		}
+		keys = append(keys, key.(string))
+		queue.Done(key)
+	}
+	return keys
+}
+
+func TestControllerEnqueueAllServicesOnlyEnabledOnes(t *testing.T) {
+	enabled := &v1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default", Name: "enabled-svc",
+			Labels: endpointsSyncEnabledService.MatchLabels,
+		},
+	}
+	disabled := &v1.Service{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "disabled-svc"},
+	}
+
+	c := &Controller{
+		serviceLister: newServiceLister(t, enabled, disabled),
+		queue:         workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter()),
+	}
+
+	c.enqueueAllServices()
+
+	keys := drainQueueKeys(t, c.queue)
+	if len(keys) != 1 || keys[0] != "default/enabled-svc" {
+		t.Errorf("enqueueAllServices() enqueued %v, want [default/enabled-svc]", keys)
+	}
+}
+
+func TestControllerEnqueueService(t *testing.T) {
+	c := &Controller{
+		queue: workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter()),
+	}
+
+	c.enqueueService(&v1.Service{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "svc"}})
+
+	keys := drainQueueKeys(t, c.queue)
+	if len(keys) != 1 || keys[0] != "ns/svc" {
+		t.Errorf("enqueueService() enqueued %v, want [ns/svc]", keys)
+	}
+}
@@ -0,0 +1,40 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync/atomic"
+	"time"
+)
+
+// lastSuccessfulSyncUnix is updated by recordSuccessfulSync after every
+// reconcileBinding that successfully writes endpoints, so /healthz can report
+// how long it's been since we last made progress.
+var lastSuccessfulSyncUnix int64
+
+func recordSuccessfulSync() {
+	atomic.StoreInt64(&lastSuccessfulSyncUnix, time.Now().Unix())
+}
+
+type healthzResponse struct {
+	ProbeWorkersAlive  bool       `json:"probeWorkersAlive"`
+	LastSuccessfulSync *time.Time `json:"lastSuccessfulSync,omitempty"`
+}
+
+// healthzHandler backs /healthz, used as a Kubernetes liveness/readiness probe.
+// It reports unhealthy if the active-probe worker pool has lost a worker, which
+// would otherwise silently stop health-checking endpoints.
+func healthzHandler(w http.ResponseWriter, r *http.Request) {
+	resp := healthzResponse{ProbeWorkersAlive: prober.WorkersAlive()}
+
+	if last := atomic.LoadInt64(&lastSuccessfulSyncUnix); last > 0 {
+		t := time.Unix(last, 0).UTC()
+		resp.LastSuccessfulSync = &t
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if !resp.ProbeWorkersAlive {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	json.NewEncoder(w).Encode(resp)
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/leaderelection"
+	"k8s.io/client-go/tools/leaderelection/resourcelock"
+)
+
+const leaderElectionLockName = "endpoints-operator"
+
+// runWithLeaderElection blocks running runFunc for as long as this process holds the
+// endpoints-operator leader lease, so multiple replicas of the operator can be run for
+// HA without racing each other to write the same Endpoints objects. It returns once
+// stopc is closed, or once this process loses the lease after having held it; it
+// does not try to acquire the lease again after that.
+func runWithLeaderElection(clientset kubernetes.Interface, stopc <-chan struct{}, runFunc func(stopc <-chan struct{})) {
+	identity := *podName
+	if identity == "" {
+		hostname, err := os.Hostname()
+		if err != nil {
+			logrus.Fatalf("unable to determine leader election identity: %s", err)
+		}
+		identity = hostname
+	}
+
+	lock := &resourcelock.LeaseLock{
+		LeaseMeta: metav1.ObjectMeta{
+			Name:      leaderElectionLockName,
+			Namespace: *leaderElectionNamespace,
+		},
+		Client: clientset.CoordinationV1(),
+		LockConfig: resourcelock.ResourceLockConfig{
+			Identity: identity,
+		},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-stopc
+		cancel()
+	}()
+
+	leaderelection.RunOrDie(ctx, leaderelection.LeaderElectionConfig{
+		Lock:            lock,
+		ReleaseOnCancel: true,
+		LeaseDuration:   15 * time.Second,
+		RenewDeadline:   10 * time.Second,
+		RetryPeriod:     2 * time.Second,
+		Callbacks:       leaderCallbacks(runFunc),
+	})
+}
+
+// leaderCallbacks builds the leaderelection.LeaderCallbacks that bridge a
+// leader-scoped context.Context into runFunc's <-chan struct{} stop channel.
+// leaderCtx is canceled as soon as this replica loses the lease, so runFunc
+// must stop on it rather than on the process-global stopc - otherwise a
+// replica that lost leadership keeps reconciling and writing
+// Endpoints/EndpointSlices alongside the new leader.
+func leaderCallbacks(runFunc func(stopc <-chan struct{})) leaderelection.LeaderCallbacks {
+	return leaderelection.LeaderCallbacks{
+		OnStartedLeading: func(leaderCtx context.Context) {
+			logrus.Info("acquired leader lease, starting controller")
+			runFunc(leaderCtx.Done())
+		},
+		OnStoppedLeading: func() {
+			logrus.Info("lost leader lease")
+		},
+		OnNewLeader: func(identity string) {
+			logrus.Infof("new leader elected: %s", identity)
+		},
+	}
+}
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestLeaderCallbacksStopsRunFuncWhenLeaseLost(t *testing.T) {
+	started := make(chan (<-chan struct{}), 1)
+	runFunc := func(stopc <-chan struct{}) { started <- stopc }
+
+	callbacks := leaderCallbacks(runFunc)
+
+	leaderCtx, cancel := context.WithCancel(context.Background())
+	callbacks.OnStartedLeading(leaderCtx)
+
+	var gotStop <-chan struct{}
+	select {
+	case gotStop = <-started:
+	case <-time.After(time.Second):
+		t.Fatal("OnStartedLeading never called runFunc")
+	}
+
+	select {
+	case <-gotStop:
+		t.Fatal("runFunc's stop channel closed before the leader context was canceled")
+	default:
+	}
+
+	// Losing the lease cancels the leader-scoped context; runFunc must stop on
+	// that, not only on the process-global shutdown channel, or a replica that
+	// lost leadership keeps reconciling alongside the new leader.
+	cancel()
+
+	select {
+	case <-gotStop:
+	case <-time.After(time.Second):
+		t.Fatal("runFunc's stop channel did not close after the leader context was canceled")
+	}
+}
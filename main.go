@@ -2,7 +2,6 @@ package main
 
 import (
 	"flag"
-	"fmt"
 	"net/http"
 	"os"
 	"path/filepath"
@@ -11,33 +10,50 @@ import (
 
 	"github.com/fortnoxab/fnxlogrus"
 	"github.com/jonaz/gograce"
-	"github.com/pkg/errors"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/sirupsen/logrus"
-	v1 "k8s.io/api/core/v1"
-	apierrors "k8s.io/apimachinery/pkg/api/errors"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/labels"
 	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
+	discoveryv1client "k8s.io/client-go/kubernetes/typed/discovery/v1"
 
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
 	"k8s.io/client-go/util/homedir"
+
+	endpointsoperatorclientset "github.com/fortnoxab/endpoints-operator/pkg/client/clientset/versioned"
 )
 
 var metricsAddr = flag.String("listen-address", ":8080", "The address to listen on for HTTP metrics requests.")
 var logLevel = flag.String("log-level", "info", "loglevel")
+var leaderElectionNamespace = flag.String("leader-election-namespace", "kube-system", "namespace to create the leader election lock in")
+var podName = flag.String("pod-name", os.Getenv("POD_NAME"), "identity to use for the leader election lock, defaults to $POD_NAME")
+var workers = flag.Int("workers", defaultWorkerCount, "number of worker goroutines draining the sync queue, per controller")
 
 var kubeClient corev1client.CoreV1Interface
+var discoveryClient discoveryv1client.DiscoveryV1Interface
 
 func main() {
 	flag.Parse()
 	fnxlogrus.Init(fnxlogrus.Config{Format: "json", Level: *logLevel}, logrus.StandardLogger())
 
-	kubeClient = getKubeClient()
+	config := getRestConfig()
+
+	clientset, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		logrus.Error("error kubernetes.NewForConfig")
+		panic(err)
+	}
+	kubeClient = clientset.CoreV1()
+	discoveryClient = clientset.DiscoveryV1()
+
+	bindingClientset, err := endpointsoperatorclientset.NewForConfig(config)
+	if err != nil {
+		logrus.Error("error building NodeEndpointBinding clientset")
+		panic(err)
+	}
 
 	http.Handle("/metrics", promhttp.Handler())
+	http.HandleFunc("/healthz", healthzHandler)
 	srv, shutdown := gograce.NewServerWithTimeout(10 * time.Second)
 	srv.Handler = http.DefaultServeMux
 	srv.Addr = *metricsAddr
@@ -53,146 +69,19 @@ func main() {
 		}
 	}()
 
-	go periodicSyncer(shutdown)
-	<-shutdown
-	wg.Wait()
-}
-
-func periodicSyncer(stopc <-chan struct{}) {
-	syncAndLog()
-	ticker := time.NewTicker(2 * time.Minute)
-	defer ticker.Stop()
-	for {
-		select {
-		case <-stopc:
-			return
-		case <-ticker.C:
-			syncAndLog()
-		}
+	annotationController := NewControllerWithWorkers(clientset, *workers)
+	bindingController := NewNodeEndpointBindingControllerWithWorkers(bindingClientset, clientset, *workers)
+	runControllers := func(stopc <-chan struct{}) {
+		go annotationController.Run(stopc)
+		bindingController.Run(stopc)
 	}
-}
-
-var endpointsSyncEnabledService = metav1.LabelSelector{MatchLabels: map[string]string{"endpoints-operator.fnox.se/enabled": "true"}}
+	go runWithLeaderElection(clientset, shutdown, runControllers)
 
-const nodeSelectorLabel = "endpoints-operator.fnox.se/node-selector"
-
-func syncAndLog() {
-	servicesToCheck, err := kubeClient.Services("").List(metav1.ListOptions{
-		LabelSelector: labels.Set(endpointsSyncEnabledService.MatchLabels).String(),
-	})
-	if err != nil {
-		logrus.Error(err)
-		return
-	}
-
-	for _, svc := range servicesToCheck.Items {
-		nodeSelector, ok := svc.Annotations[nodeSelectorLabel]
-		if !ok {
-			logrus.Errorf("missing %s label on service %s", nodeSelectorLabel, svc.GetName())
-			continue
-		}
-
-		err := syncNodeEndpoints(svc.GetNamespace(), svc.GetName(), nodeSelector)
-		if err != nil {
-			logrus.Error(err)
-		}
-	}
-}
-
-func syncNodeEndpoints(namespace, svc, nodeSelector string) error {
-	logrus.Debugf("starting sync of %s", svc)
-	service, err := kubeClient.Services(namespace).Get(svc, metav1.GetOptions{})
-	if err != nil {
-		return err
-	}
-
-	eps := &v1.Endpoints{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:   service.Name,
-			Labels: service.Labels,
-		},
-		Subsets: []v1.EndpointSubset{
-			{
-				Ports: []v1.EndpointPort{},
-			},
-		},
-	}
-
-	for _, port := range service.Spec.Ports {
-		eps.Subsets[0].Ports = append(eps.Subsets[0].Ports, v1.EndpointPort{
-			Name: port.Name,
-			Port: port.Port,
-		})
-	}
-
-	nodes, err := kubeClient.Nodes().List(metav1.ListOptions{LabelSelector: nodeSelector})
-	if err != nil {
-		return errors.Wrap(err, "listing nodes failed")
-	}
-
-	addresses, errs := getNodeAddresses(nodes)
-	if len(errs) > 0 {
-		for _, err := range errs {
-			logrus.Warnf("error getting node address: %s", err)
-		}
-	}
-	eps.Subsets[0].Addresses = addresses
-
-	err = CreateOrUpdateEndpoints(kubeClient.Endpoints(service.GetNamespace()), eps)
-	if err != nil {
-		return errors.Wrap(err, "synchronizing kubelet endpoints object failed")
-	}
-
-	return nil
-}
-
-func CreateOrUpdateEndpoints(eclient corev1client.EndpointsInterface, eps *v1.Endpoints) error {
-	endpoints, err := eclient.Get(eps.Name, metav1.GetOptions{})
-	if err != nil && !apierrors.IsNotFound(err) {
-		return errors.Wrap(err, "retrieving existing kubelet endpoints object failed")
-	}
-
-	if apierrors.IsNotFound(err) {
-		_, err = eclient.Create(eps)
-		if err != nil {
-			return errors.Wrap(err, "creating kubelet endpoints object failed")
-		}
-	} else {
-		eps.ResourceVersion = endpoints.ResourceVersion
-		_, err = eclient.Update(eps)
-		if err != nil {
-			return errors.Wrap(err, "updating kubelet endpoints object failed")
-		}
-	}
-
-	return nil
-}
-
-func getNodeAddresses(nodes *v1.NodeList) ([]v1.EndpointAddress, []error) {
-	addresses := make([]v1.EndpointAddress, 0)
-	errs := make([]error, 0)
-
-	for _, n := range nodes.Items {
-		address, _, err := nodeAddress(n)
-		if err != nil {
-			errs = append(errs, errors.Wrapf(err, "failed to determine hostname for node (%s)", n.Name))
-			continue
-		}
-		addresses = append(addresses, v1.EndpointAddress{
-			IP: address,
-			TargetRef: &v1.ObjectReference{
-				Kind:       "Node",
-				Name:       n.Name,
-				UID:        n.UID,
-				APIVersion: n.APIVersion,
-			},
-		})
-	}
-
-	return addresses, errs
+	<-shutdown
+	wg.Wait()
 }
 
-func getKubeClient() corev1client.CoreV1Interface {
+func getRestConfig() *rest.Config {
 	var kubeconfig string
 	if os.Getenv("KUBECONFIG") != "" {
 		kubeconfig = os.Getenv("KUBECONFIG")
@@ -207,31 +96,5 @@ func getKubeClient() corev1client.CoreV1Interface {
 			panic(err.Error())
 		}
 	}
-
-	clientset, err := kubernetes.NewForConfig(config)
-	if err != nil {
-		logrus.Error("error kubernetes.NewForConfig")
-		panic(err)
-	}
-	return clientset.CoreV1()
-}
-
-// nodeAddresses returns the provided node's address, based on the priority:
-// 1. NodeInternalIP
-// 2. NodeExternalIP
-//
-// Copied from github.com/prometheus/prometheus/discovery/kubernetes/node.go
-func nodeAddress(node v1.Node) (string, map[v1.NodeAddressType][]string, error) {
-	m := map[v1.NodeAddressType][]string{}
-	for _, a := range node.Status.Addresses {
-		m[a.Type] = append(m[a.Type], a.Address)
-	}
-
-	if addresses, ok := m[v1.NodeInternalIP]; ok {
-		return addresses[0], m, nil
-	}
-	if addresses, ok := m[v1.NodeExternalIP]; ok {
-		return addresses[0], m, nil
-	}
-	return "", m, fmt.Errorf("host address unknown")
+	return config
 }
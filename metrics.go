@@ -0,0 +1,32 @@
+package main
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// nodeIncludedMetric records the most recent inclusion/exclusion decision for
+// each (node, service) pair considered during a sync, along with why.
+var nodeIncludedMetric = promauto.NewGaugeVec(prometheus.GaugeOpts{
+	Name: "endpoints_operator_node_included",
+	Help: "Whether a node was included in a service's endpoints on the last sync (1) or not (0), labeled by reason.",
+}, []string{"node", "service", "reason"})
+
+// probeDurationMetric records how long each active endpoint health probe took.
+var probeDurationMetric = promauto.NewHistogramVec(prometheus.HistogramOpts{
+	Name: "endpoints_operator_probe_duration_seconds",
+	Help: "Duration of active endpoint health probes.",
+}, []string{"node", "service", "port", "type"})
+
+// probeFailuresMetric counts failed active endpoint health probes.
+var probeFailuresMetric = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "endpoints_operator_probe_failures_total",
+	Help: "Total number of failed active endpoint health probes.",
+}, []string{"node", "service", "port", "type"})
+
+// resetNodeIncludedMetric drops every node-inclusion series previously recorded
+// for service, so a node that leaves the cluster (or stops matching the
+// service's selector) doesn't leave a stale series behind forever.
+func resetNodeIncludedMetric(service string) {
+	nodeIncludedMetric.DeletePartialMatch(prometheus.Labels{"service": service})
+}
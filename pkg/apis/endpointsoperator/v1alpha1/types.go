@@ -0,0 +1,74 @@
+package v1alpha1
+
+import (
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// NodeEndpointBinding is the typed replacement for the annotation-driven
+// configuration endpoints-operator previously read off a Service. It describes
+// which nodes should back a Service's endpoints and how.
+type NodeEndpointBinding struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec NodeEndpointBindingSpec `json:"spec"`
+}
+
+// NodeEndpointBindingSpec mirrors what used to live in
+// endpoints-operator.fnox.se/* Service annotations. The probe-* settings have no
+// field here; they are still read from the target Service's annotations.
+type NodeEndpointBindingSpec struct {
+	// TargetServiceRef is the Service whose Endpoints/EndpointSlices this binding
+	// manages.
+	TargetServiceRef ServiceReference `json:"targetServiceRef"`
+
+	// NodeSelector selects the nodes to include, same semantics as the old
+	// endpoints-operator.fnox.se/node-selector annotation value.
+	NodeSelector metav1.LabelSelector `json:"nodeSelector"`
+
+	// AddressType is a comma-separated NodeAddressType priority list, e.g.
+	// "InternalIP,ExternalIP". Empty means InternalIP,ExternalIP.
+	AddressType string `json:"addressType,omitempty"`
+
+	// AddressCIDR restricts address selection to addresses inside this CIDR.
+	AddressCIDR string `json:"addressCIDR,omitempty"`
+
+	// Ports lists the ports to publish, with optional per-port node overrides.
+	Ports []PortBinding `json:"ports,omitempty"`
+
+	// Tolerations allows this binding to include nodes with matching
+	// NoSchedule/NoExecute taints.
+	Tolerations []v1.Toleration `json:"tolerations,omitempty"`
+
+	// EndpointSliceMode, when true, publishes discovery.k8s.io/v1 EndpointSlices
+	// instead of the legacy v1.Endpoints object.
+	EndpointSliceMode bool `json:"endpointSliceMode,omitempty"`
+}
+
+// ServiceReference points at the Service a binding manages. Namespace defaults to
+// the binding's own namespace when empty.
+type ServiceReference struct {
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+}
+
+// PortBinding is one published port, with an optional node-side override, e.g. to
+// front a kubelet port or NodePort instead of the Service's own port number.
+type PortBinding struct {
+	Name             string `json:"name,omitempty"`
+	Port             int32  `json:"port"`
+	NodePortOverride *int32 `json:"nodePortOverride,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// NodeEndpointBindingList is a list of NodeEndpointBinding.
+type NodeEndpointBindingList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []NodeEndpointBinding `json:"items"`
+}
@@ -0,0 +1,80 @@
+package sync
+
+import (
+	"github.com/pkg/errors"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
+)
+
+// EndpointsWriter upserts the legacy v1.Endpoints object for a Target.
+type EndpointsWriter struct {
+	client corev1client.EndpointsInterface
+}
+
+func NewEndpointsWriter(client corev1client.EndpointsInterface) *EndpointsWriter {
+	return &EndpointsWriter{client: client}
+}
+
+func (w *EndpointsWriter) Upsert(t Target) error {
+	eps := &v1.Endpoints{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   t.Name,
+			Labels: t.Labels,
+		},
+		Subsets: []v1.EndpointSubset{
+			{
+				Ports: t.Ports,
+			},
+		},
+	}
+
+	for _, a := range t.Addresses {
+		ea := v1.EndpointAddress{
+			IP:        a.IP,
+			NodeName:  stringPtr(a.NodeName),
+			TargetRef: a.TargetRef,
+		}
+		if a.Ready {
+			eps.Subsets[0].Addresses = append(eps.Subsets[0].Addresses, ea)
+		} else {
+			eps.Subsets[0].NotReadyAddresses = append(eps.Subsets[0].NotReadyAddresses, ea)
+		}
+	}
+
+	existing, err := w.client.Get(eps.Name, metav1.GetOptions{})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return errors.Wrap(err, "retrieving existing kubelet endpoints object failed")
+	}
+
+	if apierrors.IsNotFound(err) {
+		_, err = w.client.Create(eps)
+		if err != nil {
+			return errors.Wrap(err, "creating kubelet endpoints object failed")
+		}
+	} else {
+		eps.ResourceVersion = existing.ResourceVersion
+		_, err = w.client.Update(eps)
+		if err != nil {
+			return errors.Wrap(err, "updating kubelet endpoints object failed")
+		}
+	}
+
+	return nil
+}
+
+// Delete removes the legacy v1.Endpoints object named name, if it exists.
+func (w *EndpointsWriter) Delete(name string) error {
+	if err := w.client.Delete(name, &metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+		return errors.Wrap(err, "deleting kubelet endpoints object failed")
+	}
+	return nil
+}
+
+func stringPtr(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
@@ -0,0 +1,181 @@
+package sync
+
+import (
+	"fmt"
+	"net"
+	"sort"
+
+	"github.com/pkg/errors"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	discoveryv1client "k8s.io/client-go/kubernetes/typed/discovery/v1"
+)
+
+// EndpointSliceWriter upserts one discovery.k8s.io/v1 EndpointSlice per
+// (zone, address family) for a Target, so kube-proxy topology-aware routing and
+// slice-based load balancers see zone-local and dual-stack addresses correctly.
+type EndpointSliceWriter struct {
+	client discoveryv1client.EndpointSliceInterface
+}
+
+func NewEndpointSliceWriter(client discoveryv1client.EndpointSliceInterface) *EndpointSliceWriter {
+	return &EndpointSliceWriter{client: client}
+}
+
+func (w *EndpointSliceWriter) Upsert(t Target) error {
+	wantSlices := buildSlices(t)
+
+	existing, err := w.client.List(metav1.ListOptions{
+		LabelSelector: discoveryv1.LabelServiceName + "=" + t.Name,
+	})
+	if err != nil {
+		return errors.Wrap(err, "listing existing endpointslices failed")
+	}
+
+	seen := make(map[string]bool, len(wantSlices))
+	for _, slice := range wantSlices {
+		seen[slice.Name] = true
+
+		var current *discoveryv1.EndpointSlice
+		for i := range existing.Items {
+			if existing.Items[i].Name == slice.Name {
+				current = &existing.Items[i]
+				break
+			}
+		}
+
+		if current == nil {
+			if _, err := w.client.Create(slice); err != nil {
+				return errors.Wrapf(err, "creating endpointslice %s failed", slice.Name)
+			}
+			continue
+		}
+
+		slice.ResourceVersion = current.ResourceVersion
+		if _, err := w.client.Update(slice); err != nil {
+			return errors.Wrapf(err, "updating endpointslice %s failed", slice.Name)
+		}
+	}
+
+	for _, stale := range existing.Items {
+		if seen[stale.Name] {
+			continue
+		}
+		if err := w.client.Delete(stale.Name, &metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+			return errors.Wrapf(err, "deleting stale endpointslice %s failed", stale.Name)
+		}
+	}
+
+	return nil
+}
+
+// Delete removes every EndpointSlice previously written for the Service named
+// name, if any exist.
+func (w *EndpointSliceWriter) Delete(name string) error {
+	existing, err := w.client.List(metav1.ListOptions{
+		LabelSelector: discoveryv1.LabelServiceName + "=" + name,
+	})
+	if err != nil {
+		return errors.Wrap(err, "listing existing endpointslices failed")
+	}
+
+	for _, slice := range existing.Items {
+		if err := w.client.Delete(slice.Name, &metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+			return errors.Wrapf(err, "deleting endpointslice %s failed", slice.Name)
+		}
+	}
+
+	return nil
+}
+
+// buildSlices groups a Target's addresses by zone and address family, since an
+// EndpointSlice's AddressType is fixed for the whole slice.
+func buildSlices(t Target) []*discoveryv1.EndpointSlice {
+	type key struct {
+		zone        string
+		addressType discoveryv1.AddressType
+	}
+	grouped := map[key][]Address{}
+	for _, a := range t.Addresses {
+		k := key{zone: a.Zone, addressType: addressTypeOf(a.IP)}
+		grouped[k] = append(grouped[k], a)
+	}
+
+	keys := make([]key, 0, len(grouped))
+	for k := range grouped {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].zone != keys[j].zone {
+			return keys[i].zone < keys[j].zone
+		}
+		return keys[i].addressType < keys[j].addressType
+	})
+
+	ports := make([]discoveryv1.EndpointPort, 0, len(t.Ports))
+	for _, p := range t.Ports {
+		name, port := p.Name, p.Port
+		ports = append(ports, discoveryv1.EndpointPort{Name: &name, Port: &port})
+	}
+
+	slices := make([]*discoveryv1.EndpointSlice, 0, len(keys))
+	for _, k := range keys {
+		slice := &discoveryv1.EndpointSlice{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   sliceName(t.Name, k.zone, k.addressType),
+				Labels: sliceLabels(t.Labels, t.Name),
+			},
+			AddressType: k.addressType,
+			Ports:       ports,
+		}
+
+		for _, a := range grouped[k] {
+			a := a
+			ready := a.Ready
+			endpoint := discoveryv1.Endpoint{
+				Addresses:  []string{a.IP},
+				Conditions: discoveryv1.EndpointConditions{Ready: &ready},
+				TargetRef:  a.TargetRef,
+			}
+			if a.NodeName != "" {
+				endpoint.NodeName = &a.NodeName
+			}
+			if a.Zone != "" {
+				endpoint.Zone = &a.Zone
+			}
+			slice.Endpoints = append(slice.Endpoints, endpoint)
+		}
+
+		slices = append(slices, slice)
+	}
+
+	return slices
+}
+
+func sliceLabels(svcLabels map[string]string, serviceName string) map[string]string {
+	labels := make(map[string]string, len(svcLabels)+1)
+	for k, v := range svcLabels {
+		labels[k] = v
+	}
+	labels[discoveryv1.LabelServiceName] = serviceName
+	return labels
+}
+
+func sliceName(serviceName, zone string, addressType discoveryv1.AddressType) string {
+	family := "ipv4"
+	if addressType == discoveryv1.AddressTypeIPv6 {
+		family = "ipv6"
+	}
+	if zone == "" {
+		return fmt.Sprintf("%s-%s", serviceName, family)
+	}
+	return fmt.Sprintf("%s-%s-%s", serviceName, zone, family)
+}
+
+func addressTypeOf(ip string) discoveryv1.AddressType {
+	if parsed := net.ParseIP(ip); parsed != nil && parsed.To4() == nil {
+		return discoveryv1.AddressTypeIPv6
+	}
+	return discoveryv1.AddressTypeIPv4
+}
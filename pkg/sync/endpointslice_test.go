@@ -0,0 +1,82 @@
+package sync
+
+import (
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+)
+
+func TestBuildSlicesGroupsByZoneAndAddressFamily(t *testing.T) {
+	target := Target{
+		Name: "my-svc",
+		Ports: []v1.EndpointPort{
+			{Name: "http", Port: 8080},
+		},
+		Addresses: []Address{
+			{IP: "10.0.0.1", NodeName: "node-a", Zone: "eu-west-1a", Ready: true},
+			{IP: "10.0.0.2", NodeName: "node-b", Zone: "eu-west-1a", Ready: false},
+			{IP: "10.0.0.3", NodeName: "node-c", Zone: "eu-west-1b", Ready: true},
+			{IP: "fd00::1", NodeName: "node-d", Zone: "eu-west-1a", Ready: true},
+		},
+	}
+
+	slices := buildSlices(target)
+
+	if len(slices) != 3 {
+		t.Fatalf("expected 3 slices (1a-ipv4, 1a-ipv6, 1b-ipv4), got %d", len(slices))
+	}
+
+	byName := make(map[string]*discoveryv1.EndpointSlice, len(slices))
+	for _, s := range slices {
+		byName[s.Name] = s
+	}
+
+	ipv4a, ok := byName["my-svc-eu-west-1a-ipv4"]
+	if !ok {
+		t.Fatalf("expected slice %q, got names %v", "my-svc-eu-west-1a-ipv4", byName)
+	}
+	if len(ipv4a.Endpoints) != 2 {
+		t.Fatalf("expected 2 endpoints in %s, got %d", ipv4a.Name, len(ipv4a.Endpoints))
+	}
+	if ipv4a.AddressType != discoveryv1.AddressTypeIPv4 {
+		t.Errorf("expected AddressType IPv4, got %s", ipv4a.AddressType)
+	}
+
+	ipv6a, ok := byName["my-svc-eu-west-1a-ipv6"]
+	if !ok {
+		t.Fatalf("expected slice %q, got names %v", "my-svc-eu-west-1a-ipv6", byName)
+	}
+	if ipv6a.AddressType != discoveryv1.AddressTypeIPv6 {
+		t.Errorf("expected AddressType IPv6, got %s", ipv6a.AddressType)
+	}
+
+	ipv4b, ok := byName["my-svc-eu-west-1b-ipv4"]
+	if !ok {
+		t.Fatalf("expected slice %q, got names %v", "my-svc-eu-west-1b-ipv4", byName)
+	}
+	if len(ipv4b.Endpoints) != 1 {
+		t.Fatalf("expected 1 endpoint in %s, got %d", ipv4b.Name, len(ipv4b.Endpoints))
+	}
+}
+
+func TestSliceNameNoZone(t *testing.T) {
+	if got := sliceName("svc", "", discoveryv1.AddressTypeIPv4); got != "svc-ipv4" {
+		t.Errorf("sliceName with no zone = %q, want %q", got, "svc-ipv4")
+	}
+}
+
+func TestAddressTypeOf(t *testing.T) {
+	tests := []struct {
+		ip   string
+		want discoveryv1.AddressType
+	}{
+		{"10.0.0.1", discoveryv1.AddressTypeIPv4},
+		{"fd00::1", discoveryv1.AddressTypeIPv6},
+	}
+	for _, tt := range tests {
+		if got := addressTypeOf(tt.ip); got != tt.want {
+			t.Errorf("addressTypeOf(%q) = %s, want %s", tt.ip, got, tt.want)
+		}
+	}
+}
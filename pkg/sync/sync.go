@@ -0,0 +1,38 @@
+// Package sync upserts the node-backed endpoint representation for a Service,
+// independent of which Kubernetes resource kind it is ultimately written as.
+package sync
+
+import (
+	v1 "k8s.io/api/core/v1"
+)
+
+// Address is one node's contribution to a Service's endpoint set.
+type Address struct {
+	IP        string
+	NodeName  string
+	Zone      string
+	Ready     bool
+	TargetRef *v1.ObjectReference
+}
+
+// Target describes the desired endpoint state for one Service.
+type Target struct {
+	Namespace string
+	Name      string
+	Labels    map[string]string
+	Ports     []v1.EndpointPort
+	Addresses []Address
+}
+
+// Writer upserts a Target as a concrete Kubernetes resource (v1.Endpoints,
+// discovery.k8s.io/v1 EndpointSlice, ...). Implementations must be idempotent.
+type Writer interface {
+	Upsert(t Target) error
+
+	// Delete removes any resources this Writer previously wrote for the named
+	// Service, if present. It's a no-op when there's nothing to clean up, so
+	// callers can use it to clear out a different Writer kind's leftovers
+	// after a Service switches sync mode without having to track whether it
+	// ever wrote anything.
+	Delete(name string) error
+}
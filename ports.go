@@ -0,0 +1,39 @@
+package main
+
+import (
+	"encoding/json"
+
+	"github.com/pkg/errors"
+	v1 "k8s.io/api/core/v1"
+)
+
+const targetPortOverridesAnnotation = "endpoints-operator.fnox.se/target-port-overrides"
+
+// parseTargetPortOverrides reads the endpoints-operator.fnox.se/target-port-overrides
+// annotation, a JSON map of port name to node port, letting a service front a fixed
+// port on the node (e.g. the kubelet read-only port or a NodePort) instead of the
+// service's own port number.
+func parseTargetPortOverrides(service *v1.Service) (map[string]int32, error) {
+	raw, ok := service.Annotations[targetPortOverridesAnnotation]
+	if !ok {
+		return nil, nil
+	}
+
+	var overrides map[string]int32
+	if err := json.Unmarshal([]byte(raw), &overrides); err != nil {
+		return nil, errors.Wrapf(err, "parsing %s annotation", targetPortOverridesAnnotation)
+	}
+	return overrides, nil
+}
+
+func endpointPorts(service *v1.Service, overrides map[string]int32) []v1.EndpointPort {
+	ports := make([]v1.EndpointPort, 0, len(service.Spec.Ports))
+	for _, port := range service.Spec.Ports {
+		p := port.Port
+		if override, ok := overrides[port.Name]; ok {
+			p = override
+		}
+		ports = append(ports, v1.EndpointPort{Name: port.Name, Port: p})
+	}
+	return ports
+}
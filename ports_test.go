@@ -0,0 +1,56 @@
+package main
+
+import (
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestParseTargetPortOverrides(t *testing.T) {
+	svc := &v1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{
+				targetPortOverridesAnnotation: `{"http":30080,"metrics":10255}`,
+			},
+		},
+	}
+
+	overrides, err := parseTargetPortOverrides(svc)
+	if err != nil {
+		t.Fatalf("parseTargetPortOverrides() error = %v", err)
+	}
+	if overrides["http"] != 30080 || overrides["metrics"] != 10255 {
+		t.Errorf("parseTargetPortOverrides() = %v, want http=30080 metrics=10255", overrides)
+	}
+}
+
+func TestParseTargetPortOverridesMissingAnnotation(t *testing.T) {
+	overrides, err := parseTargetPortOverrides(&v1.Service{})
+	if err != nil || overrides != nil {
+		t.Errorf("parseTargetPortOverrides() = (%v, %v), want (nil, nil)", overrides, err)
+	}
+}
+
+func TestEndpointPorts(t *testing.T) {
+	svc := &v1.Service{
+		Spec: v1.ServiceSpec{
+			Ports: []v1.ServicePort{
+				{Name: "http", Port: 80},
+				{Name: "metrics", Port: 9090},
+			},
+		},
+	}
+
+	ports := endpointPorts(svc, map[string]int32{"http": 30080})
+
+	if len(ports) != 2 {
+		t.Fatalf("endpointPorts() returned %d ports, want 2", len(ports))
+	}
+	if ports[0].Name != "http" || ports[0].Port != 30080 {
+		t.Errorf("endpointPorts()[0] = %+v, want overridden http port 30080", ports[0])
+	}
+	if ports[1].Name != "metrics" || ports[1].Port != 9090 {
+		t.Errorf("endpointPorts()[1] = %+v, want unmodified metrics port 9090", ports[1])
+	}
+}
@@ -0,0 +1,252 @@
+package main
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// ProbeType selects the active health check performed against an endpoint.
+type ProbeType string
+
+const (
+	ProbeTypeNone  ProbeType = "none"
+	ProbeTypeTCP   ProbeType = "tcp"
+	ProbeTypeHTTP  ProbeType = "http"
+	ProbeTypeHTTPS ProbeType = "https"
+)
+
+// ProbeConfig is the resolved endpoints-operator.fnox.se/probe-* configuration
+// for one service.
+type ProbeConfig struct {
+	Type             ProbeType
+	Path             string
+	Interval         time.Duration
+	Timeout          time.Duration
+	FailureThreshold int
+}
+
+// prober is the process-wide active health checker, shared by every sync.
+var prober = NewProber(16)
+
+// ProbeTarget identifies one (namespace, service, node, port) active health check.
+type ProbeTarget struct {
+	Namespace string
+	Service   string
+	Node      string
+	Address   string
+	Port      int32
+	Config    ProbeConfig
+}
+
+func (t ProbeTarget) key() string {
+	return fmt.Sprintf("%s/%s/%s/%d", t.Namespace, t.Service, t.Node, t.Port)
+}
+
+type probeState struct {
+	cancel func()
+	config atomic.Value // ProbeConfig
+
+	mu                  sync.Mutex
+	consecutiveFailures int
+	healthy             bool
+}
+
+func (s *probeState) currentConfig() ProbeConfig {
+	return s.config.Load().(ProbeConfig)
+}
+
+// Prober runs active TCP/HTTP(S) health checks for endpoint addresses. One
+// lightweight ticker goroutine schedules each distinct (node, service, port)
+// target at its configured interval; the checks themselves run on a bounded
+// worker pool so a large fleet of targets can't spawn unbounded concurrent
+// network calls.
+type Prober struct {
+	mu     sync.Mutex
+	states map[string]*probeState
+	jobs   chan probeJob
+
+	workerCount  int
+	aliveWorkers int32
+}
+
+type probeJob struct {
+	target ProbeTarget
+	state  *probeState
+}
+
+func NewProber(workers int) *Prober {
+	p := &Prober{
+		states:      map[string]*probeState{},
+		jobs:        make(chan probeJob, workers*4),
+		workerCount: workers,
+	}
+	for i := 0; i < workers; i++ {
+		go p.worker()
+	}
+	return p
+}
+
+func (p *Prober) worker() {
+	atomic.AddInt32(&p.aliveWorkers, 1)
+	defer atomic.AddInt32(&p.aliveWorkers, -1)
+
+	for job := range p.jobs {
+		p.runProbe(job.target, job.state)
+	}
+}
+
+// WorkersAlive reports whether every probe worker goroutine is still running.
+func (p *Prober) WorkersAlive() bool {
+	return atomic.LoadInt32(&p.aliveWorkers) == int32(p.workerCount)
+}
+
+// Ensure starts monitoring target if it isn't already being monitored. If it
+// already is, target.Config is refreshed so an edited probe-* annotation
+// takes effect on the next tick instead of being frozen at the first Ensure.
+func (p *Prober) Ensure(target ProbeTarget) {
+	key := target.key()
+
+	p.mu.Lock()
+	if state, exists := p.states[key]; exists {
+		p.mu.Unlock()
+		state.config.Store(target.Config)
+		return
+	}
+
+	// state.cancel must be set before the state is published into p.states,
+	// under the same lock ForgetExcept uses to call it - otherwise a
+	// concurrent ForgetExcept for the same namespace/service could observe the
+	// new entry and call a nil cancel, panicking the worker goroutine it runs
+	// on.
+	stopc := make(chan struct{})
+	state := &probeState{healthy: true, cancel: func() { close(stopc) }}
+	state.config.Store(target.Config)
+	p.states[key] = state
+	p.mu.Unlock()
+
+	go p.schedule(target, state, stopc)
+}
+
+func (p *Prober) schedule(target ProbeTarget, state *probeState, stopc chan struct{}) {
+	ticker := time.NewTicker(state.currentConfig().Interval)
+	defer ticker.Stop()
+
+	p.jobs <- probeJob{target: target, state: state}
+	for {
+		select {
+		case <-stopc:
+			return
+		case <-ticker.C:
+			// Reset to the live interval every tick so a changed probe-interval
+			// annotation is picked up without tearing down the goroutine.
+			ticker.Reset(state.currentConfig().Interval)
+			select {
+			case p.jobs <- probeJob{target: target, state: state}:
+			default:
+				logrus.Warnf("probe worker pool saturated, dropped a tick for %s", target.key())
+			}
+		}
+	}
+}
+
+// ForgetExcept stops monitoring targets belonging to namespace/service that
+// aren't in keep, e.g. because a node or port was removed from the service.
+func (p *Prober) ForgetExcept(namespace, service string, keep map[string]bool) {
+	prefix := namespace + "/" + service + "/"
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for key, state := range p.states {
+		if !strings.HasPrefix(key, prefix) || keep[key] {
+			continue
+		}
+		state.cancel()
+		delete(p.states, key)
+	}
+}
+
+// Healthy reports whether target's rolling probe state considers it healthy.
+// Targets that haven't been registered yet are treated as healthy so a
+// brand-new node isn't needlessly marked not-ready before its first probe runs.
+func (p *Prober) Healthy(target ProbeTarget) bool {
+	p.mu.Lock()
+	state, ok := p.states[target.key()]
+	p.mu.Unlock()
+	if !ok {
+		return true
+	}
+
+	state.mu.Lock()
+	defer state.mu.Unlock()
+	return state.healthy
+}
+
+func (p *Prober) runProbe(target ProbeTarget, state *probeState) {
+	cfg := state.currentConfig()
+	labels := []string{target.Node, target.Service, strconv.Itoa(int(target.Port)), string(cfg.Type)}
+
+	start := time.Now()
+	err := doProbe(target.Address, target.Port, cfg)
+	probeDurationMetric.WithLabelValues(labels...).Observe(time.Since(start).Seconds())
+
+	state.mu.Lock()
+	defer state.mu.Unlock()
+
+	if err != nil {
+		probeFailuresMetric.WithLabelValues(labels...).Inc()
+		state.consecutiveFailures++
+		if state.consecutiveFailures >= cfg.FailureThreshold {
+			state.healthy = false
+		}
+		return
+	}
+
+	state.consecutiveFailures = 0
+	state.healthy = true
+}
+
+func doProbe(address string, port int32, cfg ProbeConfig) error {
+	addr := net.JoinHostPort(address, strconv.Itoa(int(port)))
+
+	switch cfg.Type {
+	case ProbeTypeTCP:
+		conn, err := net.DialTimeout("tcp", addr, cfg.Timeout)
+		if err != nil {
+			return err
+		}
+		return conn.Close()
+
+	case ProbeTypeHTTP, ProbeTypeHTTPS:
+		scheme := "http"
+		if cfg.Type == ProbeTypeHTTPS {
+			scheme = "https"
+		}
+		client := &http.Client{
+			Timeout:   cfg.Timeout,
+			Transport: &http.Transport{TLSClientConfig: &tls.Config{InsecureSkipVerify: true}},
+		}
+
+		resp, err := client.Get(fmt.Sprintf("%s://%s%s", scheme, addr, cfg.Path))
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode >= 400 {
+			return fmt.Errorf("probe returned status %d", resp.StatusCode)
+		}
+		return nil
+
+	default:
+		return nil
+	}
+}
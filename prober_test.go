@@ -0,0 +1,69 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func newTestProbeState(cfg ProbeConfig) *probeState {
+	s := &probeState{healthy: true}
+	s.config.Store(cfg)
+	return s
+}
+
+func TestProberRunProbeFailureThreshold(t *testing.T) {
+	p := &Prober{states: map[string]*probeState{}, jobs: make(chan probeJob)}
+
+	target := ProbeTarget{Namespace: "ns", Service: "svc", Node: "node-a", Address: "127.0.0.1", Port: 1}
+	cfg := ProbeConfig{Type: ProbeTypeTCP, Timeout: time.Millisecond, FailureThreshold: 3}
+	state := newTestProbeState(cfg)
+	p.states[target.key()] = state
+
+	// port 1 is never listening locally, so every probe fails.
+	for i := 1; i <= 2; i++ {
+		p.runProbe(target, state)
+		if !p.Healthy(target) {
+			t.Fatalf("state should stay healthy before reaching the failure threshold (attempt %d)", i)
+		}
+		if state.consecutiveFailures != i {
+			t.Errorf("consecutiveFailures = %d, want %d", state.consecutiveFailures, i)
+		}
+	}
+
+	p.runProbe(target, state)
+	state.mu.Lock()
+	healthy := state.healthy
+	state.mu.Unlock()
+	if healthy {
+		t.Error("state should be unhealthy once consecutiveFailures reaches FailureThreshold")
+	}
+}
+
+func TestProberRunProbeRecovers(t *testing.T) {
+	p := &Prober{}
+
+	target := ProbeTarget{Namespace: "ns", Service: "svc", Node: "node-a", Address: "127.0.0.1", Port: 1}
+	cfg := ProbeConfig{Type: ProbeTypeNone, FailureThreshold: 1}
+	state := newTestProbeState(cfg)
+	state.consecutiveFailures = 1
+	state.healthy = false
+
+	// ProbeTypeNone always "succeeds", so the next probe should clear failures.
+	p.runProbe(target, state)
+
+	state.mu.Lock()
+	defer state.mu.Unlock()
+	if !state.healthy || state.consecutiveFailures != 0 {
+		t.Errorf("state = {healthy: %v, consecutiveFailures: %d}, want {healthy: true, consecutiveFailures: 0}",
+			state.healthy, state.consecutiveFailures)
+	}
+}
+
+func TestProberHealthyUnknownTargetDefaultsHealthy(t *testing.T) {
+	p := &Prober{states: map[string]*probeState{}}
+
+	target := ProbeTarget{Namespace: "ns", Service: "svc", Node: "node-a", Port: 1}
+	if !p.Healthy(target) {
+		t.Error("Healthy() should default to true for a target with no recorded probe state yet")
+	}
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"strconv"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+
+	nodesync "github.com/fortnoxab/endpoints-operator/pkg/sync"
+)
+
+const probeTypeAnnotation = "endpoints-operator.fnox.se/probe-type"
+const probePathAnnotation = "endpoints-operator.fnox.se/probe-path"
+const probeIntervalAnnotation = "endpoints-operator.fnox.se/probe-interval"
+const probeTimeoutAnnotation = "endpoints-operator.fnox.se/probe-timeout"
+const probeFailureThresholdAnnotation = "endpoints-operator.fnox.se/probe-failure-threshold"
+
+const defaultProbeInterval = 10 * time.Second
+const defaultProbeTimeout = 2 * time.Second
+const defaultProbeFailureThreshold = 3
+const defaultProbePath = "/"
+
+// parseProbeConfig reads the endpoints-operator.fnox.se/probe-* annotations,
+// letting a service opt in to active TCP/HTTP(S) health checks against each
+// node address on top of the node's own Ready condition. A missing
+// or "none" probe-type disables active probing entirely.
+func parseProbeConfig(service *v1.Service) ProbeConfig {
+	cfg := ProbeConfig{
+		Type:             ProbeType(service.Annotations[probeTypeAnnotation]),
+		Path:             service.Annotations[probePathAnnotation],
+		Interval:         defaultProbeInterval,
+		Timeout:          defaultProbeTimeout,
+		FailureThreshold: defaultProbeFailureThreshold,
+	}
+
+	if cfg.Type == "" {
+		cfg.Type = ProbeTypeNone
+	}
+	if cfg.Path == "" {
+		cfg.Path = defaultProbePath
+	}
+
+	if v, err := time.ParseDuration(service.Annotations[probeIntervalAnnotation]); err == nil {
+		cfg.Interval = v
+	}
+	if v, err := time.ParseDuration(service.Annotations[probeTimeoutAnnotation]); err == nil {
+		cfg.Timeout = v
+	}
+	if v, err := strconv.Atoi(service.Annotations[probeFailureThresholdAnnotation]); err == nil && v > 0 {
+		cfg.FailureThreshold = v
+	}
+
+	return cfg
+}
+
+// applyProbes registers (or keeps registered) an active health check for every
+// (address, port) pair published for service and overrides each address's
+// Ready flag with the probe's rolling healthy/unhealthy verdict, so a node that
+// fails its checks moves into NotReadyAddresses even though kubelet still
+// reports it Ready. Addresses belonging to ports no longer published, or to a
+// service that stops probing, are forgotten so their prober goroutines exit.
+func applyProbes(service *v1.Service, ports []v1.EndpointPort, addresses []nodesync.Address) []nodesync.Address {
+	cfg := parseProbeConfig(service)
+	if cfg.Type == ProbeTypeNone || len(ports) == 0 {
+		prober.ForgetExcept(service.Namespace, service.Name, nil)
+		return addresses
+	}
+
+	keep := make(map[string]bool, len(addresses)*len(ports))
+	result := make([]nodesync.Address, len(addresses))
+	for i, a := range addresses {
+		healthy := true
+		for _, port := range ports {
+			target := ProbeTarget{
+				Namespace: service.Namespace,
+				Service:   service.Name,
+				Node:      a.NodeName,
+				Address:   a.IP,
+				Port:      port.Port,
+				Config:    cfg,
+			}
+			prober.Ensure(target)
+			keep[target.key()] = true
+			if !prober.Healthy(target) {
+				healthy = false
+			}
+		}
+
+		a.Ready = a.Ready && healthy
+		result[i] = a
+	}
+
+	prober.ForgetExcept(service.Namespace, service.Name, keep)
+	return result
+}
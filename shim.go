@@ -0,0 +1,70 @@
+package main
+
+import (
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	endpointsoperatorv1alpha1 "github.com/fortnoxab/endpoints-operator/pkg/apis/endpointsoperator/v1alpha1"
+)
+
+// ephemeralBindingFromService translates a Service's endpoints-operator.fnox.se/*
+// annotations into an in-memory NodeEndpointBinding, so services configured the
+// old, annotation-driven way keep working against the same reconcileBinding path
+// a real NodeEndpointBinding takes. It is never persisted to the API server.
+func ephemeralBindingFromService(svc *v1.Service, nodeSelector string) (*endpointsoperatorv1alpha1.NodeEndpointBinding, error) {
+	tolerations, err := parseTolerations(svc)
+	if err != nil {
+		return nil, err
+	}
+
+	overrides, err := parseTargetPortOverrides(svc)
+	if err != nil {
+		return nil, err
+	}
+
+	ports := make([]endpointsoperatorv1alpha1.PortBinding, 0, len(svc.Spec.Ports))
+	for _, port := range svc.Spec.Ports {
+		pb := endpointsoperatorv1alpha1.PortBinding{Name: port.Name, Port: port.Port}
+		if override, ok := overrides[port.Name]; ok {
+			pb.NodePortOverride = &override
+		}
+		ports = append(ports, pb)
+	}
+
+	binding := &endpointsoperatorv1alpha1.NodeEndpointBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: svc.Namespace,
+			Name:      svc.Name,
+		},
+		Spec: endpointsoperatorv1alpha1.NodeEndpointBindingSpec{
+			TargetServiceRef:  endpointsoperatorv1alpha1.ServiceReference{Namespace: svc.Namespace, Name: svc.Name},
+			NodeSelector:      metav1.LabelSelector{MatchLabels: map[string]string{}},
+			AddressType:       svc.Annotations[addressTypeAnnotation],
+			AddressCIDR:       svc.Annotations[addressCIDRAnnotation],
+			Ports:             ports,
+			Tolerations:       tolerations,
+			EndpointSliceMode: svc.Annotations[modeAnnotation] == modeEndpointSlice,
+		},
+	}
+
+	// The legacy annotation stores a label selector *string* (e.g. "role=worker"),
+	// not a structured metav1.LabelSelector, so it's carried through as a raw
+	// selector rather than parsed into MatchLabels/MatchExpressions.
+	binding.Annotations = map[string]string{legacyNodeSelectorAnnotation: nodeSelector}
+
+	return binding, nil
+}
+
+const legacyNodeSelectorAnnotation = "endpoints-operator.fnox.se/raw-node-selector"
+
+// bindingNodeSelector resolves a binding's node selector. Ephemeral bindings
+// produced by the annotation shim carry the legacy raw selector string verbatim
+// (it isn't always expressible as a structured metav1.LabelSelector), while real
+// NodeEndpointBindings use the typed NodeSelector field.
+func bindingNodeSelector(binding *endpointsoperatorv1alpha1.NodeEndpointBinding) (labels.Selector, error) {
+	if raw, ok := binding.Annotations[legacyNodeSelectorAnnotation]; ok {
+		return labels.Parse(raw)
+	}
+	return metav1.LabelSelectorAsSelector(&binding.Spec.NodeSelector)
+}
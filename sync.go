@@ -0,0 +1,73 @@
+package main
+
+import (
+	"net"
+
+	"github.com/pkg/errors"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	nodesync "github.com/fortnoxab/endpoints-operator/pkg/sync"
+)
+
+var endpointsSyncEnabledService = metav1.LabelSelector{MatchLabels: map[string]string{"endpoints-operator.fnox.se/enabled": "true"}}
+
+const nodeSelectorLabel = "endpoints-operator.fnox.se/node-selector"
+
+const modeAnnotation = "endpoints-operator.fnox.se/mode"
+const modeEndpointSlice = "endpointslice"
+
+const zoneLabel = "topology.kubernetes.io/zone"
+
+func getNodeAddresses(nodes *v1.NodeList, serviceName string, tolerations []v1.Toleration, priority []v1.NodeAddressType, cidr *net.IPNet) ([]nodesync.Address, []error) {
+	// Recorded fresh every sync so nodes that left the cluster or stopped
+	// matching the service's selector don't leave a stale series behind.
+	resetNodeIncludedMetric(serviceName)
+
+	addresses := make([]nodesync.Address, 0)
+	errs := make([]error, 0)
+
+	for _, n := range nodes.Items {
+		if ok, reason := nodeSchedulable(n, tolerations); !ok {
+			nodeIncludedMetric.WithLabelValues(n.Name, serviceName, reason).Set(0)
+			continue
+		}
+
+		address, err := nodeAddress(n, priority, cidr)
+		if err != nil {
+			errs = append(errs, errors.Wrapf(err, "failed to determine address for node (%s)", n.Name))
+			continue
+		}
+
+		ready := nodeReady(n)
+		reason := "not_ready"
+		if ready {
+			reason = "ready"
+		}
+		nodeIncludedMetric.WithLabelValues(n.Name, serviceName, reason).Set(1)
+
+		addresses = append(addresses, nodesync.Address{
+			IP:       address,
+			NodeName: n.Name,
+			Zone:     n.Labels[zoneLabel],
+			Ready:    ready,
+			TargetRef: &v1.ObjectReference{
+				Kind:       "Node",
+				Name:       n.Name,
+				UID:        n.UID,
+				APIVersion: n.APIVersion,
+			},
+		})
+	}
+
+	return addresses, errs
+}
+
+func nodeReady(node v1.Node) bool {
+	for _, c := range node.Status.Conditions {
+		if c.Type == v1.NodeReady {
+			return c.Status == v1.ConditionTrue
+		}
+	}
+	return false
+}
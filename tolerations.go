@@ -0,0 +1,74 @@
+package main
+
+import (
+	"encoding/json"
+
+	"github.com/pkg/errors"
+	v1 "k8s.io/api/core/v1"
+)
+
+const tolerationsAnnotation = "endpoints-operator.fnox.se/tolerations"
+
+// parseTolerations reads the endpoints-operator.fnox.se/tolerations annotation, a
+// JSON array of v1.Toleration, that lets a service opt in to nodes carrying
+// NoSchedule/NoExecute taints the operator would otherwise exclude.
+func parseTolerations(service *v1.Service) ([]v1.Toleration, error) {
+	raw, ok := service.Annotations[tolerationsAnnotation]
+	if !ok {
+		return nil, nil
+	}
+
+	var tolerations []v1.Toleration
+	if err := json.Unmarshal([]byte(raw), &tolerations); err != nil {
+		return nil, errors.Wrapf(err, "parsing %s annotation", tolerationsAnnotation)
+	}
+	return tolerations, nil
+}
+
+// nodeSchedulable reports whether a node should be considered for a service's
+// endpoints at all, ignoring readiness. Unschedulable nodes are always excluded;
+// nodes with a NoSchedule/NoExecute taint are excluded unless the service
+// tolerates that taint.
+func nodeSchedulable(node v1.Node, tolerations []v1.Toleration) (bool, string) {
+	if node.Spec.Unschedulable {
+		return false, "unschedulable"
+	}
+
+	for _, taint := range node.Spec.Taints {
+		if taint.Effect != v1.TaintEffectNoSchedule && taint.Effect != v1.TaintEffectNoExecute {
+			continue
+		}
+		if !tolerated(taint, tolerations) {
+			return false, "tainted"
+		}
+	}
+
+	return true, ""
+}
+
+func tolerated(taint v1.Taint, tolerations []v1.Toleration) bool {
+	for _, t := range tolerations {
+		if tolerationToleratesTaint(t, taint) {
+			return true
+		}
+	}
+	return false
+}
+
+func tolerationToleratesTaint(t v1.Toleration, taint v1.Taint) bool {
+	if t.Effect != "" && t.Effect != taint.Effect {
+		return false
+	}
+	if t.Key != "" && t.Key != taint.Key {
+		return false
+	}
+
+	switch t.Operator {
+	case v1.TolerationOpExists:
+		return true
+	case v1.TolerationOpEqual, "":
+		return t.Value == taint.Value
+	default:
+		return false
+	}
+}
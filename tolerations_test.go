@@ -0,0 +1,117 @@
+package main
+
+import (
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestNodeSchedulable(t *testing.T) {
+	noScheduleTaint := v1.Taint{Key: "dedicated", Value: "batch", Effect: v1.TaintEffectNoSchedule}
+
+	tests := []struct {
+		name        string
+		node        v1.Node
+		tolerations []v1.Toleration
+		wantOK      bool
+		wantReason  string
+	}{
+		{
+			name:   "plain node",
+			node:   v1.Node{},
+			wantOK: true,
+		},
+		{
+			name:   "unschedulable",
+			node:   v1.Node{Spec: v1.NodeSpec{Unschedulable: true}},
+			wantOK: false, wantReason: "unschedulable",
+		},
+		{
+			name:   "tainted, no tolerations",
+			node:   v1.Node{Spec: v1.NodeSpec{Taints: []v1.Taint{noScheduleTaint}}},
+			wantOK: false, wantReason: "tainted",
+		},
+		{
+			name: "tainted, tolerated by key+effect",
+			node: v1.Node{Spec: v1.NodeSpec{Taints: []v1.Taint{noScheduleTaint}}},
+			tolerations: []v1.Toleration{
+				{Key: "dedicated", Operator: v1.TolerationOpExists, Effect: v1.TaintEffectNoSchedule},
+			},
+			wantOK: true,
+		},
+		{
+			name: "tainted, tolerated by equal value",
+			node: v1.Node{Spec: v1.NodeSpec{Taints: []v1.Taint{noScheduleTaint}}},
+			tolerations: []v1.Toleration{
+				{Key: "dedicated", Operator: v1.TolerationOpEqual, Value: "batch", Effect: v1.TaintEffectNoSchedule},
+			},
+			wantOK: true,
+		},
+		{
+			name: "tainted, mismatched value not tolerated",
+			node: v1.Node{Spec: v1.NodeSpec{Taints: []v1.Taint{noScheduleTaint}}},
+			tolerations: []v1.Toleration{
+				{Key: "dedicated", Operator: v1.TolerationOpEqual, Value: "other", Effect: v1.TaintEffectNoSchedule},
+			},
+			wantOK: false, wantReason: "tainted",
+		},
+		{
+			name: "PreferNoSchedule taint is always allowed",
+			node: v1.Node{Spec: v1.NodeSpec{Taints: []v1.Taint{
+				{Key: "dedicated", Effect: v1.TaintEffectPreferNoSchedule},
+			}}},
+			wantOK: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ok, reason := nodeSchedulable(tt.node, tt.tolerations)
+			if ok != tt.wantOK || reason != tt.wantReason {
+				t.Errorf("nodeSchedulable() = (%v, %q), want (%v, %q)", ok, reason, tt.wantOK, tt.wantReason)
+			}
+		})
+	}
+}
+
+func TestParseTolerations(t *testing.T) {
+	svc := &v1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{
+				tolerationsAnnotation: `[{"key":"dedicated","operator":"Equal","value":"batch","effect":"NoSchedule"}]`,
+			},
+		},
+	}
+
+	tolerations, err := parseTolerations(svc)
+	if err != nil {
+		t.Fatalf("parseTolerations() error = %v", err)
+	}
+	if len(tolerations) != 1 || tolerations[0].Key != "dedicated" {
+		t.Errorf("parseTolerations() = %+v, want one toleration for key dedicated", tolerations)
+	}
+}
+
+func TestParseTolerationsMissingAnnotation(t *testing.T) {
+	svc := &v1.Service{}
+
+	tolerations, err := parseTolerations(svc)
+	if err != nil || tolerations != nil {
+		t.Errorf("parseTolerations() = (%v, %v), want (nil, nil)", tolerations, err)
+	}
+}
+
+func TestParseTolerationsInvalidJSON(t *testing.T) {
+	svc := &v1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{
+				tolerationsAnnotation: "not json",
+			},
+		},
+	}
+
+	if _, err := parseTolerations(svc); err == nil {
+		t.Error("parseTolerations() expected error for malformed annotation, got nil")
+	}
+}